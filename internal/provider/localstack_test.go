@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+// getLocalStackConfig returns an aws.Config pointed at a local LocalStack
+// instance, or nil if LocalStack is not running.
+func getLocalStackConfig(t *testing.T) *aws.Config {
+	t.Helper()
+
+	if !localStackRunning() {
+		return nil
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background(),
+		config.WithRegion("us-east-1"),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("test", "test", "")),
+		config.WithBaseEndpoint("http://localhost:4566"),
+	)
+	if err != nil {
+		t.Fatalf("failed to load localstack config: %v", err)
+	}
+
+	return &cfg
+}
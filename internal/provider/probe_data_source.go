@@ -0,0 +1,473 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &probeDataSource{}
+var _ datasource.DataSourceWithConfigure = &probeDataSource{}
+
+// probeDataSource implements the `probe` data source, which checks for the
+// existence of a single resource and reports what it can discover about it.
+type probeDataSource struct {
+	registry *ProberRegistry
+}
+
+// probeDataSourceModel describes the data source data model.
+type probeDataSourceModel struct {
+	Type          types.String  `tfsdk:"type"`
+	ID            types.String  `tfsdk:"id"`
+	Region        types.String  `tfsdk:"region"`
+	AssumeRoleArn types.String  `tfsdk:"assume_role_arn"`
+	ExternalID    types.String  `tfsdk:"external_id"`
+	SessionName   types.String  `tfsdk:"session_name"`
+	Expect        []expectModel `tfsdk:"expect"`
+	WaitFor       *waitForModel `tfsdk:"wait_for"`
+	Exists        types.Bool    `tfsdk:"exists"`
+	Arn           types.String  `tfsdk:"arn"`
+	Properties    types.Map     `tfsdk:"properties"`
+	Tags          types.Map     `tfsdk:"tags"`
+	Matched       types.Bool    `tfsdk:"matched"`
+	Mismatches    types.List    `tfsdk:"mismatches"`
+}
+
+// expectModel describes a single `expect` block: an assertion made against a
+// value resolved from `result.Properties`.
+type expectModel struct {
+	Path     types.String `tfsdk:"path"`
+	Equals   types.String `tfsdk:"equals"`
+	Matches  types.String `tfsdk:"matches"`
+	Required types.Bool   `tfsdk:"required"`
+}
+
+// waitForModel describes the `wait_for` block: poll the resource until it
+// reaches a desired state, or give up after timeout.
+type waitForModel struct {
+	Exists   types.Bool   `tfsdk:"exists"`
+	Timeout  types.String `tfsdk:"timeout"`
+	Interval types.String `tfsdk:"interval"`
+	Property types.String `tfsdk:"property"`
+	Equals   types.String `tfsdk:"equals"`
+}
+
+// NewProbeDataSource returns a new instance of the probe data source.
+func NewProbeDataSource() datasource.DataSource {
+	return &probeDataSource{}
+}
+
+func (d *probeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName
+}
+
+func (d *probeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks for the existence of a resource and exposes its ARN, properties, and tags.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Required:    true,
+				Description: "Resource type to probe, in Cloud Control (`AWS::S3::Bucket`), Terraform (`aws_s3_bucket`), or short (`s3_bucket`) form. To probe a non-AWS backend declared in the provider's `backends` block, prefix with the backend name, e.g. `spaces:do_spaces_bucket`.",
+			},
+			"id": schema.StringAttribute{
+				Required:    true,
+				Description: "Identifier of the resource to probe (e.g. bucket name, table name).",
+			},
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Description: "Overrides the provider's region for this probe, e.g. to check a resource in another region.",
+			},
+			"assume_role_arn": schema.StringAttribute{
+				Optional:    true,
+				Description: "ARN of an IAM role to assume for this probe, e.g. to check a resource in another account.",
+			},
+			"external_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "External ID to pass when assuming `assume_role_arn`.",
+			},
+			"session_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Session name to use when assuming `assume_role_arn`. Defaults to the AWS SDK's standard session name.",
+			},
+			"exists": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the resource exists.",
+			},
+			"arn": schema.StringAttribute{
+				Computed:    true,
+				Description: "ARN of the resource, if it exists.",
+			},
+			"properties": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Properties discovered about the resource, if it exists.",
+			},
+			"tags": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Tags discovered on the resource, if it exists.",
+			},
+			"matched": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether every `expect` block matched. Always `true` when no `expect` blocks are configured.",
+			},
+			"mismatches": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Human-readable description of each `expect` block that did not match.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"expect": schema.ListNestedBlock{
+				Description: "Asserts on a value resolved from the probed resource's properties. May be repeated.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							Required:    true,
+							Description: "Dot-separated path into `properties` to assert on, e.g. `BucketName` or `Tags.Environment`.",
+						},
+						"equals": schema.StringAttribute{
+							Optional:    true,
+							Description: "The resolved value must equal this string exactly.",
+						},
+						"matches": schema.StringAttribute{
+							Optional:    true,
+							Description: "The resolved value must match this regular expression.",
+						},
+						"required": schema.BoolAttribute{
+							Optional:    true,
+							Description: "If true, a mismatch fails the plan/apply instead of only being reported in `mismatches`.",
+						},
+					},
+				},
+			},
+			"wait_for": schema.SingleNestedBlock{
+				Description: "Polls the resource until it reaches a desired state instead of reading it once.",
+				Attributes: map[string]schema.Attribute{
+					"exists": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Poll until the resource's existence matches this value.",
+					},
+					"timeout": schema.StringAttribute{
+						Optional:    true,
+						Description: "Maximum time to poll, as a Go duration string (e.g. `5m`). Defaults to `5m`.",
+					},
+					"interval": schema.StringAttribute{
+						Optional:    true,
+						Description: "Time to wait between polls, as a Go duration string (e.g. `10s`). Defaults to `10s`.",
+					},
+					"property": schema.StringAttribute{
+						Optional:    true,
+						Description: "Dot-separated path into `properties` to poll, using the same resolution as `expect`.",
+					},
+					"equals": schema.StringAttribute{
+						Optional:    true,
+						Description: "Poll until `property` resolves to this value.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *probeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*probeProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *probeProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.registry = providerData.Registry
+}
+
+func (d *probeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data probeDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendName, resourceType := splitBackendType(data.Type.ValueString())
+
+	var prober Prober
+	var err error
+	if backendName != "" {
+		overrides := RegionRoleOverrides{
+			Region:        data.Region.ValueString(),
+			AssumeRoleArn: data.AssumeRoleArn.ValueString(),
+			ExternalID:    data.ExternalID.ValueString(),
+			SessionName:   data.SessionName.ValueString(),
+		}
+		if !overrides.isZero() {
+			resp.Diagnostics.AddError(
+				"Conflicting Type and Overrides",
+				fmt.Sprintf("type %q addresses backend %q, which always uses that backend's own configuration. "+
+					"region, assume_role_arn, external_id, and session_name are not supported together with a backend-qualified type; remove them or drop the backend prefix from type.",
+					data.Type.ValueString(), backendName),
+			)
+			return
+		}
+		prober, err = d.registry.GetProberForBackend(ctx, backendName, resourceType)
+	} else {
+		overrides := RegionRoleOverrides{
+			Region:        data.Region.ValueString(),
+			AssumeRoleArn: data.AssumeRoleArn.ValueString(),
+			ExternalID:    data.ExternalID.ValueString(),
+			SessionName:   data.SessionName.ValueString(),
+		}
+		prober, err = d.registry.GetProberFor(resourceType, overrides)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Unsupported Resource Type", err.Error())
+		return
+	}
+
+	var result *ProbeResult
+	if data.WaitFor != nil {
+		result, err = waitForResult(ctx, prober, data.ID.ValueString(), *data.WaitFor)
+	} else {
+		result, err = prober.Probe(ctx, data.ID.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Probe Failed", err.Error())
+		return
+	}
+
+	data.Exists = types.BoolValue(result.Exists)
+
+	if !result.Exists {
+		data.Arn = types.StringNull()
+		data.Properties = types.MapNull(types.StringType)
+		data.Tags = types.MapNull(types.StringType)
+	} else {
+		data.Arn = types.StringValue(result.Arn)
+
+		props, diags := stringMapFromProperties(result.Properties)
+		resp.Diagnostics.Append(diags...)
+		data.Properties = props
+
+		tags, diags := stringMapFromTags(result.Tags)
+		resp.Diagnostics.Append(diags...)
+		data.Tags = tags
+	}
+
+	matched, mismatches, requiredFailure, err := evaluateExpectations(result.Exists, result.Properties, data.Expect)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Expectation", err.Error())
+		return
+	}
+	if requiredFailure != "" {
+		resp.Diagnostics.AddError("Required Expectation Not Met", requiredFailure)
+	}
+
+	data.Matched = types.BoolValue(matched)
+
+	mismatchList, diags := types.ListValueFrom(ctx, types.StringType, mismatches)
+	resp.Diagnostics.Append(diags...)
+	data.Mismatches = mismatchList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// evaluateExpectations checks every `expect` block against properties,
+// returning whether they all matched, a human-readable mismatch per failure,
+// and (if any failed `expect` block had `required = true`) the message for
+// the first such failure.
+func evaluateExpectations(exists bool, properties map[string]interface{}, expectations []expectModel) (matched bool, mismatches []string, requiredFailure string, err error) {
+	matched = true
+
+	for _, exp := range expectations {
+		path := exp.Path.ValueString()
+
+		if !exists {
+			msg := fmt.Sprintf("%s: resource does not exist", path)
+			matched = false
+			mismatches = append(mismatches, msg)
+			if exp.Required.ValueBool() && requiredFailure == "" {
+				requiredFailure = msg
+			}
+			continue
+		}
+
+		value, ok := resolveProperty(properties, path)
+		msg := ""
+		switch {
+		case !ok:
+			msg = fmt.Sprintf("%s: property not found", path)
+		case !exp.Equals.IsNull() && value != exp.Equals.ValueString():
+			msg = fmt.Sprintf("%s: got %q, want equals=%q", path, value, exp.Equals.ValueString())
+		case !exp.Matches.IsNull():
+			re, reErr := regexp.Compile(exp.Matches.ValueString())
+			if reErr != nil {
+				return false, nil, "", fmt.Errorf("invalid regex for %q: %w", path, reErr)
+			}
+			if !re.MatchString(value) {
+				msg = fmt.Sprintf("%s: got %q, want matches=%q", path, value, exp.Matches.ValueString())
+			}
+		}
+
+		if msg != "" {
+			matched = false
+			mismatches = append(mismatches, msg)
+			if exp.Required.ValueBool() && requiredFailure == "" {
+				requiredFailure = msg
+			}
+		}
+	}
+
+	return matched, mismatches, requiredFailure, nil
+}
+
+// waitForResult polls prober for id until wf's condition is satisfied or its
+// timeout elapses, returning the last probe result either way (so callers
+// can still report what was last seen on a timeout).
+func waitForResult(ctx context.Context, prober Prober, id string, wf waitForModel) (*ProbeResult, error) {
+	timeout, err := parseDurationOrDefault(wf.Timeout.ValueString(), 5*time.Minute)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wait_for.timeout: %w", err)
+	}
+	interval, err := parseDurationOrDefault(wf.Interval.ValueString(), 10*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wait_for.interval: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		result, err := prober.Probe(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if waitForConditionMet(result, wf) {
+			return result, nil
+		}
+
+		if time.Now().After(deadline) {
+			return result, fmt.Errorf("timed out after %s waiting for %q to satisfy wait_for", timeout, id)
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// waitForConditionMet reports whether result already satisfies wf's exists
+// and/or property conditions.
+func waitForConditionMet(result *ProbeResult, wf waitForModel) bool {
+	if !wf.Exists.IsNull() && result.Exists != wf.Exists.ValueBool() {
+		return false
+	}
+
+	if path := wf.Property.ValueString(); path != "" {
+		if !result.Exists {
+			return false
+		}
+		value, ok := resolveProperty(result.Properties, path)
+		if !ok {
+			return false
+		}
+		if !wf.Equals.IsNull() && value != wf.Equals.ValueString() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseDurationOrDefault parses s as a Go duration, returning def if s is
+// empty.
+func parseDurationOrDefault(s string, def time.Duration) (time.Duration, error) {
+	if s == "" {
+		return def, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// splitBackendType splits a `type` value of the form "backend:resourceType"
+// into its backend name and resource type. Inputs with no single ":" before
+// the resource type (including Cloud Control types such as
+// "AWS::S3::Bucket", whose first ":" starts a "::") have no backend (the
+// default AWS backend applies) and are returned unchanged as the resource
+// type.
+func splitBackendType(input string) (backend, resourceType string) {
+	if idx := strings.Index(input, ":"); idx >= 0 && !strings.HasPrefix(input[idx:], "::") {
+		return input[:idx], input[idx+1:]
+	}
+	return "", input
+}
+
+// resolveProperty resolves a dot-separated path (e.g. "Tags.Environment")
+// against a property bag, descending into nested maps. The resolved value is
+// stringified for comparison.
+func resolveProperty(properties map[string]interface{}, path string) (string, bool) {
+	var current interface{} = properties
+	for _, part := range strings.Split(path, ".") {
+		switch m := current.(type) {
+		case map[string]interface{}:
+			val, ok := m[part]
+			if !ok {
+				return "", false
+			}
+			current = val
+		case map[string]string:
+			val, ok := m[part]
+			if !ok {
+				return "", false
+			}
+			current = val
+		default:
+			return "", false
+		}
+	}
+	return fmt.Sprintf("%v", current), true
+}
+
+// stringMapFromProperties converts a loosely-typed property bag into a
+// types.Map of strings, stringifying non-string values.
+func stringMapFromProperties(properties map[string]interface{}) (types.Map, diag.Diagnostics) {
+	if len(properties) == 0 {
+		return types.MapNull(types.StringType), nil
+	}
+
+	elems := make(map[string]attr.Value, len(properties))
+	for k, v := range properties {
+		elems[k] = types.StringValue(fmt.Sprintf("%v", v))
+	}
+	return types.MapValue(types.StringType, elems)
+}
+
+// stringMapFromTags converts a tag map into a types.Map, returning a null
+// map when there are no tags.
+func stringMapFromTags(tags map[string]string) (types.Map, diag.Diagnostics) {
+	if len(tags) == 0 {
+		return types.MapNull(types.StringType), nil
+	}
+
+	elems := make(map[string]attr.Value, len(tags))
+	for k, v := range tags {
+		elems[k] = types.StringValue(v)
+	}
+	return types.MapValue(types.StringType, elems)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
@@ -218,3 +219,321 @@ data "probe" "test" {
   id   = "nonexistent-bucket-terraform-syntax-12345"
 }
 `
+
+func TestResolveProperty(t *testing.T) {
+	properties := map[string]interface{}{
+		"BucketName": "my-bucket",
+		"Count":      3,
+		"Tags": map[string]string{
+			"Environment": "prod",
+		},
+		"Nested": map[string]interface{}{
+			"Inner": "value",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+		wantOk   bool
+	}{
+		{
+			name:     "top-level string property",
+			path:     "BucketName",
+			expected: "my-bucket",
+			wantOk:   true,
+		},
+		{
+			name:     "top-level non-string property is stringified",
+			path:     "Count",
+			expected: "3",
+			wantOk:   true,
+		},
+		{
+			name:     "nested path into map[string]string",
+			path:     "Tags.Environment",
+			expected: "prod",
+			wantOk:   true,
+		},
+		{
+			name:     "nested path into map[string]interface{}",
+			path:     "Nested.Inner",
+			expected: "value",
+			wantOk:   true,
+		},
+		{
+			name:   "missing top-level property",
+			path:   "Missing",
+			wantOk: false,
+		},
+		{
+			name:   "missing nested property",
+			path:   "Tags.Missing",
+			wantOk: false,
+		},
+		{
+			name:   "path into a non-map value fails",
+			path:   "BucketName.Sub",
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := resolveProperty(properties, tt.path)
+			if ok != tt.wantOk {
+				t.Fatalf("resolveProperty(%q) ok = %v, want %v", tt.path, ok, tt.wantOk)
+			}
+			if ok && value != tt.expected {
+				t.Errorf("resolveProperty(%q) = %q, want %q", tt.path, value, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDurationOrDefault(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		def      time.Duration
+		expected time.Duration
+		wantErr  bool
+	}{
+		{
+			name:     "empty string returns default",
+			s:        "",
+			def:      5 * time.Minute,
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "valid duration is parsed",
+			s:        "30s",
+			def:      5 * time.Minute,
+			expected: 30 * time.Second,
+		},
+		{
+			name:    "invalid duration errors",
+			s:       "not-a-duration",
+			def:     5 * time.Minute,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseDurationOrDefault(tt.s, tt.def)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("parseDurationOrDefault(%q, %v) = %v, want %v", tt.s, tt.def, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWaitForConditionMet(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   *ProbeResult
+		wf       waitForModel
+		expected bool
+	}{
+		{
+			name:     "no conditions is always met",
+			result:   &ProbeResult{Exists: false},
+			wf:       waitForModel{},
+			expected: true,
+		},
+		{
+			name:     "exists condition satisfied",
+			result:   &ProbeResult{Exists: true},
+			wf:       waitForModel{Exists: types.BoolValue(true)},
+			expected: true,
+		},
+		{
+			name:     "exists condition not satisfied",
+			result:   &ProbeResult{Exists: false},
+			wf:       waitForModel{Exists: types.BoolValue(true)},
+			expected: false,
+		},
+		{
+			name:   "property condition satisfied",
+			result: &ProbeResult{Exists: true, Properties: map[string]interface{}{"TableStatus": "ACTIVE"}},
+			wf: waitForModel{
+				Property: types.StringValue("TableStatus"),
+				Equals:   types.StringValue("ACTIVE"),
+			},
+			expected: true,
+		},
+		{
+			name:   "property condition not satisfied",
+			result: &ProbeResult{Exists: true, Properties: map[string]interface{}{"TableStatus": "CREATING"}},
+			wf: waitForModel{
+				Property: types.StringValue("TableStatus"),
+				Equals:   types.StringValue("ACTIVE"),
+			},
+			expected: false,
+		},
+		{
+			name:   "property missing from an existing resource is not met",
+			result: &ProbeResult{Exists: true, Properties: map[string]interface{}{}},
+			wf: waitForModel{
+				Property: types.StringValue("TableStatus"),
+				Equals:   types.StringValue("ACTIVE"),
+			},
+			expected: false,
+		},
+		{
+			name:   "property condition on a nonexistent resource is not met",
+			result: &ProbeResult{Exists: false},
+			wf: waitForModel{
+				Property: types.StringValue("TableStatus"),
+				Equals:   types.StringValue("ACTIVE"),
+			},
+			expected: false,
+		},
+		{
+			name:   "property condition without equals only requires presence",
+			result: &ProbeResult{Exists: true, Properties: map[string]interface{}{"TableStatus": "CREATING"}},
+			wf: waitForModel{
+				Property: types.StringValue("TableStatus"),
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := waitForConditionMet(tt.result, tt.wf); result != tt.expected {
+				t.Errorf("waitForConditionMet() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluateExpectations(t *testing.T) {
+	properties := map[string]interface{}{
+		"BucketName": "my-bucket",
+		"Tags": map[string]string{
+			"Environment": "prod",
+		},
+	}
+
+	tests := []struct {
+		name                string
+		exists              bool
+		expect              []expectModel
+		wantMatched         bool
+		wantMismatches      int
+		wantRequiredFailure bool
+		wantErr             bool
+	}{
+		{
+			name:        "no expectations always matches",
+			exists:      true,
+			wantMatched: true,
+		},
+		{
+			name:   "equals expectation satisfied",
+			exists: true,
+			expect: []expectModel{
+				{Path: types.StringValue("BucketName"), Equals: types.StringValue("my-bucket")},
+			},
+			wantMatched: true,
+		},
+		{
+			name:   "equals expectation not satisfied",
+			exists: true,
+			expect: []expectModel{
+				{Path: types.StringValue("BucketName"), Equals: types.StringValue("other-bucket")},
+			},
+			wantMatched:    false,
+			wantMismatches: 1,
+		},
+		{
+			name:   "matches expectation satisfied",
+			exists: true,
+			expect: []expectModel{
+				{Path: types.StringValue("Tags.Environment"), Matches: types.StringValue("^prod$")},
+			},
+			wantMatched: true,
+		},
+		{
+			name:   "matches expectation not satisfied",
+			exists: true,
+			expect: []expectModel{
+				{Path: types.StringValue("Tags.Environment"), Matches: types.StringValue("^stage$")},
+			},
+			wantMatched:    false,
+			wantMismatches: 1,
+		},
+		{
+			name:   "invalid regex returns error",
+			exists: true,
+			expect: []expectModel{
+				{Path: types.StringValue("Tags.Environment"), Matches: types.StringValue("(")},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "missing property mismatches",
+			exists: true,
+			expect: []expectModel{
+				{Path: types.StringValue("Missing"), Equals: types.StringValue("anything")},
+			},
+			wantMatched:    false,
+			wantMismatches: 1,
+		},
+		{
+			name:   "resource does not exist mismatches every expectation",
+			exists: false,
+			expect: []expectModel{
+				{Path: types.StringValue("BucketName"), Equals: types.StringValue("my-bucket")},
+			},
+			wantMatched:    false,
+			wantMismatches: 1,
+		},
+		{
+			name:   "required mismatch is surfaced as a failure",
+			exists: true,
+			expect: []expectModel{
+				{Path: types.StringValue("BucketName"), Equals: types.StringValue("other-bucket"), Required: types.BoolValue(true)},
+			},
+			wantMatched:         false,
+			wantMismatches:      1,
+			wantRequiredFailure: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, mismatches, requiredFailure, err := evaluateExpectations(tt.exists, properties, tt.expect)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if matched != tt.wantMatched {
+				t.Errorf("matched = %v, want %v", matched, tt.wantMatched)
+			}
+			if len(mismatches) != tt.wantMismatches {
+				t.Errorf("len(mismatches) = %d, want %d (%v)", len(mismatches), tt.wantMismatches, mismatches)
+			}
+			if (requiredFailure != "") != tt.wantRequiredFailure {
+				t.Errorf("requiredFailure = %q, want non-empty: %v", requiredFailure, tt.wantRequiredFailure)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &probeManyDataSource{}
+var _ datasource.DataSourceWithConfigure = &probeManyDataSource{}
+
+// probeManyDataSource implements the `probe_many` data source, which probes
+// many IDs of a single resource type concurrently.
+type probeManyDataSource struct {
+	registry       *ProberRegistry
+	maxConcurrency int
+}
+
+// probeManyDataSourceModel describes the data source data model.
+type probeManyDataSourceModel struct {
+	Type    types.String           `tfsdk:"type"`
+	IDs     []types.String         `tfsdk:"ids"`
+	Results []probeManyResultModel `tfsdk:"results"`
+}
+
+// probeManyResultModel describes a single entry of `results`.
+type probeManyResultModel struct {
+	ID         types.String `tfsdk:"id"`
+	Exists     types.Bool   `tfsdk:"exists"`
+	Arn        types.String `tfsdk:"arn"`
+	Properties types.Map    `tfsdk:"properties"`
+	Tags       types.Map    `tfsdk:"tags"`
+	Error      types.String `tfsdk:"error"`
+}
+
+// NewProbeManyDataSource returns a new instance of the probe_many data
+// source.
+func NewProbeManyDataSource() datasource.DataSource {
+	return &probeManyDataSource{}
+}
+
+func (d *probeManyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_many"
+}
+
+func (d *probeManyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Probes many IDs of a single resource type concurrently and reports what it can discover about each.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Required:    true,
+				Description: "Resource type to probe, in Cloud Control (`AWS::S3::Bucket`), Terraform (`aws_s3_bucket`), or short (`s3_bucket`) form.",
+			},
+			"ids": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "Identifiers of the resources to probe (e.g. bucket names, table names).",
+			},
+			"results": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "One entry per ID in `ids`, in the same order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID that was probed.",
+						},
+						"exists": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the resource exists.",
+						},
+						"arn": schema.StringAttribute{
+							Computed:    true,
+							Description: "ARN of the resource, if it exists.",
+						},
+						"properties": schema.MapAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "Properties discovered about the resource, if it exists.",
+						},
+						"tags": schema.MapAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "Tags discovered on the resource, if it exists.",
+						},
+						"error": schema.StringAttribute{
+							Computed:    true,
+							Description: "Error encountered probing this ID, if any. A per-ID error does not fail the rest of the query.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *probeManyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*probeProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *probeProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.registry = providerData.Registry
+	d.maxConcurrency = providerData.MaxConcurrency
+}
+
+func (d *probeManyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data probeManyDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prober, err := d.registry.GetProber(data.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unsupported Resource Type", err.Error())
+		return
+	}
+
+	ids := make([]string, len(data.IDs))
+	for i, id := range data.IDs {
+		ids[i] = id.ValueString()
+	}
+
+	data.Results = probeManyConcurrently(ctx, prober, ids, d.maxConcurrency)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// probeManyConcurrently probes every id against prober using a worker pool
+// bounded by maxConcurrency (falling back to defaultMaxConcurrency if it's
+// unset). A failure probing one ID is captured in that result's Error field
+// rather than aborting the rest.
+func probeManyConcurrently(ctx context.Context, prober Prober, ids []string, maxConcurrency int) []probeManyResultModel {
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultMaxConcurrency
+	}
+
+	results := make([]probeManyResultModel, len(ids))
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+
+	for i, id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = probeManyResult(ctx, prober, id)
+		}(i, id)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// probeManyResult probes a single id, converting either the probe result or
+// the probe error into a probeManyResultModel.
+func probeManyResult(ctx context.Context, prober Prober, id string) probeManyResultModel {
+	model := probeManyResultModel{
+		ID:         types.StringValue(id),
+		Arn:        types.StringNull(),
+		Properties: types.MapNull(types.StringType),
+		Tags:       types.MapNull(types.StringType),
+		Error:      types.StringNull(),
+	}
+
+	result, err := prober.Probe(ctx, id)
+	if err != nil {
+		model.Exists = types.BoolValue(false)
+		model.Error = types.StringValue(err.Error())
+		return model
+	}
+
+	model.Exists = types.BoolValue(result.Exists)
+	if !result.Exists {
+		return model
+	}
+
+	model.Arn = types.StringValue(result.Arn)
+
+	if props, diags := stringMapFromProperties(result.Properties); !diags.HasError() {
+		model.Properties = props
+	}
+	if tags, diags := stringMapFromTags(result.Tags); !diags.HasError() {
+		model.Tags = tags
+	}
+
+	return model
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// fakeProber is a Prober stub for exercising probeManyConcurrently without
+// talking to AWS. It fails for any id in failIDs and otherwise reports the id
+// itself as the resource's ARN.
+type fakeProber struct {
+	failIDs map[string]bool
+}
+
+func (p *fakeProber) Probe(ctx context.Context, id string) (*ProbeResult, error) {
+	if p.failIDs[id] {
+		return nil, fmt.Errorf("probing %q: simulated failure", id)
+	}
+	return &ProbeResult{Exists: true, Arn: id}, nil
+}
+
+func TestProbeManyConcurrently(t *testing.T) {
+	t.Run("probes every id and preserves order", func(t *testing.T) {
+		ids := []string{"a", "b", "c", "d", "e"}
+		prober := &fakeProber{}
+
+		results := probeManyConcurrently(context.Background(), prober, ids, 2)
+
+		if len(results) != len(ids) {
+			t.Fatalf("len(results) = %d, want %d", len(results), len(ids))
+		}
+		for i, id := range ids {
+			if results[i].ID.ValueString() != id {
+				t.Errorf("results[%d].ID = %q, want %q", i, results[i].ID.ValueString(), id)
+			}
+			if !results[i].Exists.ValueBool() {
+				t.Errorf("results[%d].Exists = false, want true", i)
+			}
+			if !results[i].Error.IsNull() {
+				t.Errorf("results[%d].Error = %q, want null", i, results[i].Error.ValueString())
+			}
+		}
+	})
+
+	t.Run("a failing id is isolated to its own result", func(t *testing.T) {
+		ids := []string{"a", "b", "c"}
+		prober := &fakeProber{failIDs: map[string]bool{"b": true}}
+
+		results := probeManyConcurrently(context.Background(), prober, ids, 2)
+
+		if len(results) != len(ids) {
+			t.Fatalf("len(results) = %d, want %d", len(results), len(ids))
+		}
+		for i, id := range ids {
+			if id == "b" {
+				if results[i].Exists.ValueBool() {
+					t.Errorf("results[%d].Exists = true for failed probe, want false", i)
+				}
+				if results[i].Error.IsNull() || results[i].Error.ValueString() == "" {
+					t.Errorf("results[%d].Error = %q, want the simulated failure message", i, results[i].Error.ValueString())
+				}
+				continue
+			}
+			if !results[i].Exists.ValueBool() {
+				t.Errorf("results[%d].Exists = false for id %q, want true", i, id)
+			}
+			if !results[i].Error.IsNull() {
+				t.Errorf("results[%d].Error = %q for id %q, want null", i, results[i].Error.ValueString(), id)
+			}
+		}
+	})
+
+	t.Run("maxConcurrency <= 0 falls back to the default", func(t *testing.T) {
+		ids := []string{"a", "b"}
+		prober := &fakeProber{}
+
+		results := probeManyConcurrently(context.Background(), prober, ids, 0)
+
+		if len(results) != len(ids) {
+			t.Fatalf("len(results) = %d, want %d", len(results), len(ids))
+		}
+	})
+}
@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import "context"
+
+// ProbeResult is the outcome of checking whether a resource exists and, if
+// so, what can be discovered about it.
+type ProbeResult struct {
+	Exists     bool
+	Arn        string
+	Properties map[string]interface{}
+	Tags       map[string]string
+}
+
+// Prober checks for the existence of a single resource identified by id and
+// reports what it can discover about it.
+type Prober interface {
+	Probe(ctx context.Context, id string) (*ProbeResult, error)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDBProber checks for the existence of a DynamoDB table and reports
+// its ARN, status, and tags.
+type DynamoDBProber struct {
+	client *dynamodb.Client
+}
+
+// NewDynamoDBProber returns a Prober for AWS::DynamoDB::Table backed by cfg.
+func NewDynamoDBProber(cfg aws.Config) *DynamoDBProber {
+	return &DynamoDBProber{client: dynamodb.NewFromConfig(cfg)}
+}
+
+// Probe checks whether the table named id exists and, if so, collects its
+// ARN, status, and tags.
+func (p *DynamoDBProber) Probe(ctx context.Context, id string) (*ProbeResult, error) {
+	out, err := p.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(id)})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return &ProbeResult{Exists: false}, nil
+		}
+		return nil, fmt.Errorf("probing DynamoDB table %q: %w", id, err)
+	}
+
+	table := out.Table
+	result := &ProbeResult{
+		Exists: true,
+		Arn:    aws.ToString(table.TableArn),
+		Properties: map[string]interface{}{
+			"TableName":   aws.ToString(table.TableName),
+			"TableStatus": string(table.TableStatus),
+		},
+	}
+
+	tagsOut, err := p.client.ListTagsOfResource(ctx, &dynamodb.ListTagsOfResourceInput{ResourceArn: table.TableArn})
+	if err != nil {
+		return nil, fmt.Errorf("reading tags for DynamoDB table %q: %w", id, err)
+	}
+	if len(tagsOut.Tags) > 0 {
+		tags := make(map[string]string, len(tagsOut.Tags))
+		for _, tag := range tagsOut.Tags {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+		result.Tags = tags
+		result.Properties["Tags"] = tags
+	}
+
+	return result, nil
+}
@@ -0,0 +1,333 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// normalizedTypes maps every supported spelling of a resource type (Cloud
+// Control, Terraform, and short form) to the canonical Terraform-style name
+// used internally as the registry key.
+var normalizedTypes = map[string]string{
+	"aws_dynamodb_table":         "aws_dynamodb_table",
+	"AWS::DynamoDB::Table":       "aws_dynamodb_table",
+	"AWS::DynamoDB::GlobalTable": "aws_dynamodb_table",
+	"dynamodb_table":             "aws_dynamodb_table",
+
+	"aws_s3_bucket":   "aws_s3_bucket",
+	"AWS::S3::Bucket": "aws_s3_bucket",
+	"s3_bucket":       "aws_s3_bucket",
+
+	"aws_s3_object":   "aws_s3_object",
+	"AWS::S3::Object": "aws_s3_object",
+	"s3_object":       "aws_s3_object",
+
+	"do_spaces_bucket":      "do_spaces_bucket",
+	"AWS::DOSpaces::Bucket": "do_spaces_bucket",
+	"dospaces_bucket":       "do_spaces_bucket",
+}
+
+// normalizeTypeName converts any supported spelling of a resource type
+// (Cloud Control's "AWS::Service::Resource", Terraform's
+// "aws_service_resource", or a short form) into the canonical Terraform-style
+// name used as the registry key. Unknown Cloud Control types are converted
+// mechanically; anything else is returned unchanged.
+func normalizeTypeName(input string) string {
+	if canonical, ok := normalizedTypes[input]; ok {
+		return canonical
+	}
+
+	if strings.HasPrefix(input, "aws_") {
+		return input
+	}
+
+	if strings.HasPrefix(input, "AWS::") {
+		parts := strings.Split(input, "::")
+		if len(parts) != 3 {
+			return input
+		}
+		return fmt.Sprintf("aws_%s_%s", strings.ToLower(parts[1]), strings.ToLower(parts[2]))
+	}
+
+	return input
+}
+
+// ProberRegistry lazily constructs and caches one Prober per resource type.
+type ProberRegistry struct {
+	cfg     aws.Config
+	mu      sync.Mutex
+	probers map[string]Prober
+
+	// scopedMu guards scoped, the set of per-(region, role) registries built
+	// on demand by GetProberFor.
+	scopedMu sync.Mutex
+	scoped   map[regionRoleKey]*ProberRegistry
+
+	// backendsMu guards backends (named non-AWS Backends registered via
+	// RegisterBackend) and backendRegistries (the nested registry built from
+	// each backend's own aws.Config, on demand, by GetProberForBackend).
+	backendsMu        sync.Mutex
+	backends          map[string]Backend
+	backendRegistries map[string]*ProberRegistry
+}
+
+// Backend supplies the aws.Config a ProberRegistry should use to talk to a
+// particular (possibly non-AWS) service. This lets probers written against
+// the AWS SDK also work against S3-API-compatible stores such as
+// DigitalOcean Spaces, MinIO, or Cloudflare R2.
+type Backend interface {
+	NewConfig(ctx context.Context) (aws.Config, error)
+}
+
+// S3CompatibleBackend is a Backend for any S3-API-compatible object store
+// reached through a custom endpoint and static credentials.
+type S3CompatibleBackend struct {
+	Endpoint        string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// NewConfig builds an aws.Config pointed at b's endpoint and region, using
+// static credentials if AccessKeyID is set or falling back to the standard
+// AWS SDK credential chain otherwise.
+func (b S3CompatibleBackend) NewConfig(ctx context.Context) (aws.Config, error) {
+	opts := []func(*config.LoadOptions) error{
+		config.WithBaseEndpoint(b.Endpoint),
+	}
+	if b.Region != "" {
+		opts = append(opts, config.WithRegion(b.Region))
+	}
+	if b.AccessKeyID != "" {
+		opts = append(opts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(b.AccessKeyID, b.SecretAccessKey, ""),
+		))
+	}
+
+	return config.LoadDefaultConfig(ctx, opts...)
+}
+
+// RegionRoleOverrides are per-invocation overrides for which account/region
+// a probe should run against, letting a single provider instance probe
+// resources that live behind different regions or IAM roles.
+type RegionRoleOverrides struct {
+	Region        string
+	AssumeRoleArn string
+	ExternalID    string
+	SessionName   string
+}
+
+// isZero reports whether no override was actually requested, so callers can
+// fall back to the registry's default (base) configuration.
+func (o RegionRoleOverrides) isZero() bool {
+	return o == RegionRoleOverrides{}
+}
+
+// regionRoleKey identifies one derived aws.Config (and the probers built
+// from it), shared across invocations that request the same
+// region/role/external-ID/session-name. All four fields are part of the key
+// because ExternalID and SessionName both change the credentials provider
+// deriveConfig builds, so two calls that only agree on Region+RoleArn must
+// not be handed the same cached registry.
+type regionRoleKey struct {
+	Region      string
+	RoleArn     string
+	ExternalID  string
+	SessionName string
+}
+
+// NewProberRegistry returns a registry that builds probers using cfg.
+func NewProberRegistry(cfg aws.Config) *ProberRegistry {
+	return &ProberRegistry{
+		cfg:     cfg,
+		probers: make(map[string]Prober),
+	}
+}
+
+// GetProber returns the Prober for typeName, constructing and caching it on
+// first use. typeName may be given in Cloud Control, Terraform, or short
+// form; it is normalized before lookup.
+func (r *ProberRegistry) GetProber(typeName string) (Prober, error) {
+	canonical := normalizeTypeName(typeName)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if prober, ok := r.probers[canonical]; ok {
+		return prober, nil
+	}
+
+	prober, err := r.newProber(canonical)
+	if err != nil {
+		return nil, err
+	}
+
+	r.probers[canonical] = prober
+	return prober, nil
+}
+
+// GetProberFor returns the Prober for typeName, scoped to the region and/or
+// IAM role in overrides. If overrides is the zero value, it behaves exactly
+// like GetProber. Otherwise it builds (and caches, keyed by region, role,
+// external ID, and session name) a
+// derived aws.Config and a nested registry of probers around it, so repeated
+// calls for the same region/role reuse clients instead of re-authenticating.
+func (r *ProberRegistry) GetProberFor(typeName string, overrides RegionRoleOverrides) (Prober, error) {
+	if overrides.isZero() {
+		return r.GetProber(typeName)
+	}
+
+	scoped, err := r.scopedRegistry(overrides)
+	if err != nil {
+		return nil, err
+	}
+	return scoped.GetProber(typeName)
+}
+
+// scopedRegistry returns (building and caching on first use) the registry
+// for overrides' region/role.
+func (r *ProberRegistry) scopedRegistry(overrides RegionRoleOverrides) (*ProberRegistry, error) {
+	key := regionRoleKey{
+		Region:      overrides.Region,
+		RoleArn:     overrides.AssumeRoleArn,
+		ExternalID:  overrides.ExternalID,
+		SessionName: overrides.SessionName,
+	}
+
+	r.scopedMu.Lock()
+	defer r.scopedMu.Unlock()
+
+	if r.scoped == nil {
+		r.scoped = make(map[regionRoleKey]*ProberRegistry)
+	}
+
+	if existing, ok := r.scoped[key]; ok {
+		return existing, nil
+	}
+
+	cfg := deriveConfig(r.cfg, overrides)
+	scoped := NewProberRegistry(cfg)
+	r.scoped[key] = scoped
+	return scoped, nil
+}
+
+// deriveConfig copies base, applying overrides' region and (if set) an
+// assume-role credentials provider.
+func deriveConfig(base aws.Config, overrides RegionRoleOverrides) aws.Config {
+	cfg := base.Copy()
+
+	if overrides.Region != "" {
+		cfg.Region = overrides.Region
+	}
+
+	if overrides.AssumeRoleArn != "" {
+		stsClient := sts.NewFromConfig(cfg)
+		provider := stscreds.NewAssumeRoleProvider(stsClient, overrides.AssumeRoleArn, func(o *stscreds.AssumeRoleOptions) {
+			if overrides.ExternalID != "" {
+				o.ExternalID = aws.String(overrides.ExternalID)
+			}
+			if overrides.SessionName != "" {
+				o.RoleSessionName = overrides.SessionName
+			}
+		})
+		cfg.Credentials = aws.NewCredentialsCache(provider)
+	}
+
+	return cfg
+}
+
+// newProber constructs a Prober for a canonical type name.
+func (r *ProberRegistry) newProber(canonical string) (Prober, error) {
+	switch canonical {
+	case "aws_dynamodb_table":
+		return NewDynamoDBProber(r.cfg), nil
+	case "aws_s3_bucket":
+		return NewS3Prober(r.cfg), nil
+	case "aws_s3_object":
+		return NewS3ObjectProber(r.cfg), nil
+	case "do_spaces_bucket":
+		return NewS3Prober(r.cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported resource type: %s", canonical)
+	}
+}
+
+// SupportedTypes returns the canonical type names this registry can probe.
+func (r *ProberRegistry) SupportedTypes() []string {
+	return []string{
+		"aws_dynamodb_table",
+		"aws_s3_bucket",
+		"aws_s3_object",
+		"do_spaces_bucket",
+	}
+}
+
+// RegisterBackend makes a named Backend available to GetProberForBackend.
+// It's how the provider wires up the `backends` block so data sources can
+// address resources by (backend, resourceType) instead of only AWS.
+func (r *ProberRegistry) RegisterBackend(name string, backend Backend) {
+	r.backendsMu.Lock()
+	defer r.backendsMu.Unlock()
+
+	if r.backends == nil {
+		r.backends = make(map[string]Backend)
+	}
+	r.backends[name] = backend
+}
+
+// GetProberForBackend returns the Prober for typeName against the named
+// backend. An empty backendName behaves exactly like GetProber. Otherwise
+// the backend's aws.Config is built (and cached) once, and a nested
+// registry of probers is built around it, same as GetProberFor.
+func (r *ProberRegistry) GetProberForBackend(ctx context.Context, backendName, typeName string) (Prober, error) {
+	if backendName == "" {
+		return r.GetProber(typeName)
+	}
+
+	scoped, err := r.backendRegistry(ctx, backendName)
+	if err != nil {
+		return nil, err
+	}
+	return scoped.GetProber(typeName)
+}
+
+// backendRegistry returns (building and caching on first use) the registry
+// for backendName.
+func (r *ProberRegistry) backendRegistry(ctx context.Context, backendName string) (*ProberRegistry, error) {
+	r.backendsMu.Lock()
+	defer r.backendsMu.Unlock()
+
+	if existing, ok := r.backendRegistries[backendName]; ok {
+		return existing, nil
+	}
+
+	backend, ok := r.backends[backendName]
+	if !ok {
+		return nil, fmt.Errorf("unknown backend: %s", backendName)
+	}
+
+	cfg, err := backend.NewConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("building config for backend %q: %w", backendName, err)
+	}
+
+	scoped := NewProberRegistry(cfg)
+
+	if r.backendRegistries == nil {
+		r.backendRegistries = make(map[string]*ProberRegistry)
+	}
+	r.backendRegistries[backendName] = scoped
+
+	return scoped, nil
+}
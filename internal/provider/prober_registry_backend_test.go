@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// fakeBackend is a Backend stub for exercising backendRegistry without
+// talking to AWS or an S3-compatible endpoint.
+type fakeBackend struct {
+	region string
+	err    error
+}
+
+func (b fakeBackend) NewConfig(ctx context.Context) (aws.Config, error) {
+	if b.err != nil {
+		return aws.Config{}, b.err
+	}
+	return aws.Config{Region: b.region}, nil
+}
+
+func TestS3CompatibleBackend_NewConfig(t *testing.T) {
+	t.Run("static credentials", func(t *testing.T) {
+		backend := S3CompatibleBackend{
+			Endpoint:        "https://nyc3.digitaloceanspaces.com",
+			Region:          "nyc3",
+			AccessKeyID:     "key",
+			SecretAccessKey: "secret",
+		}
+
+		cfg, err := backend.NewConfig(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Region != "nyc3" {
+			t.Errorf("Region = %q, want %q", cfg.Region, "nyc3")
+		}
+		if cfg.Credentials == nil {
+			t.Error("expected Credentials to be set from AccessKeyID/SecretAccessKey")
+		}
+	})
+
+	t.Run("no static credentials falls back to the default chain", func(t *testing.T) {
+		backend := S3CompatibleBackend{
+			Endpoint: "https://nyc3.digitaloceanspaces.com",
+			Region:   "nyc3",
+		}
+
+		cfg, err := backend.NewConfig(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Region != "nyc3" {
+			t.Errorf("Region = %q, want %q", cfg.Region, "nyc3")
+		}
+	})
+}
+
+func TestProberRegistry_backendRegistry(t *testing.T) {
+	registry := NewProberRegistry(aws.Config{Region: "us-east-1"})
+	registry.RegisterBackend("spaces", fakeBackend{region: "nyc3"})
+
+	t.Run("unknown backend errors", func(t *testing.T) {
+		_, err := registry.backendRegistry(context.Background(), "unknown")
+		if err == nil {
+			t.Fatal("expected error for unknown backend")
+		}
+	})
+
+	t.Run("known backend builds and caches a registry", func(t *testing.T) {
+		first, err := registry.backendRegistry(context.Background(), "spaces")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if first.cfg.Region != "nyc3" {
+			t.Errorf("backend registry Region = %q, want %q", first.cfg.Region, "nyc3")
+		}
+
+		second, err := registry.backendRegistry(context.Background(), "spaces")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if first != second {
+			t.Error("expected the same backend registry instance to be reused")
+		}
+	})
+
+	t.Run("backend config error propagates", func(t *testing.T) {
+		registry := NewProberRegistry(aws.Config{Region: "us-east-1"})
+		registry.RegisterBackend("broken", fakeBackend{err: context.DeadlineExceeded})
+
+		_, err := registry.backendRegistry(context.Background(), "broken")
+		if err == nil {
+			t.Fatal("expected error to propagate from Backend.NewConfig")
+		}
+	})
+}
+
+func TestProberRegistry_GetProberForBackend(t *testing.T) {
+	registry := NewProberRegistry(aws.Config{Region: "us-east-1"})
+	registry.RegisterBackend("spaces", fakeBackend{region: "nyc3"})
+
+	t.Run("empty backend name behaves like GetProber", func(t *testing.T) {
+		prober, err := registry.GetProberForBackend(context.Background(), "", "aws_s3_bucket")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		direct, _ := registry.GetProber("aws_s3_bucket")
+		if prober != direct {
+			t.Error("expected the same prober as GetProber")
+		}
+	})
+
+	t.Run("named backend resolves a scoped prober", func(t *testing.T) {
+		prober, err := registry.GetProberForBackend(context.Background(), "spaces", "do_spaces_bucket")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if prober == nil {
+			t.Fatal("expected prober to be non-nil")
+		}
+	})
+}
@@ -189,6 +189,145 @@ func TestProberRegistry_SupportedTypes(t *testing.T) {
 	}
 }
 
+func TestRegionRoleOverrides_isZero(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides RegionRoleOverrides
+		expected  bool
+	}{
+		{
+			name:      "zero value",
+			overrides: RegionRoleOverrides{},
+			expected:  true,
+		},
+		{
+			name:      "region set",
+			overrides: RegionRoleOverrides{Region: "us-west-2"},
+			expected:  false,
+		},
+		{
+			name:      "assume role arn set",
+			overrides: RegionRoleOverrides{AssumeRoleArn: "arn:aws:iam::123456789012:role/test"},
+			expected:  false,
+		},
+		{
+			name:      "external id set",
+			overrides: RegionRoleOverrides{ExternalID: "external-id"},
+			expected:  false,
+		},
+		{
+			name:      "session name set",
+			overrides: RegionRoleOverrides{SessionName: "session"},
+			expected:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.overrides.isZero(); result != tt.expected {
+				t.Errorf("isZero() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDeriveConfig(t *testing.T) {
+	base := aws.Config{Region: "us-east-1"}
+
+	t.Run("no overrides leaves config unchanged", func(t *testing.T) {
+		cfg := deriveConfig(base, RegionRoleOverrides{})
+		if cfg.Region != "us-east-1" {
+			t.Errorf("Region = %q, want %q", cfg.Region, "us-east-1")
+		}
+		if cfg.Credentials != base.Credentials {
+			t.Error("expected Credentials to be left unchanged")
+		}
+	})
+
+	t.Run("region override replaces region", func(t *testing.T) {
+		cfg := deriveConfig(base, RegionRoleOverrides{Region: "eu-west-1"})
+		if cfg.Region != "eu-west-1" {
+			t.Errorf("Region = %q, want %q", cfg.Region, "eu-west-1")
+		}
+	})
+
+	t.Run("assume role arn installs a credentials provider", func(t *testing.T) {
+		cfg := deriveConfig(base, RegionRoleOverrides{AssumeRoleArn: "arn:aws:iam::123456789012:role/test"})
+		if cfg.Credentials == nil {
+			t.Fatal("expected Credentials to be set")
+		}
+		if cfg.Credentials == base.Credentials {
+			t.Error("expected a new Credentials provider, got the base one")
+		}
+	})
+
+	t.Run("base config is not mutated", func(t *testing.T) {
+		_ = deriveConfig(base, RegionRoleOverrides{Region: "eu-west-1", AssumeRoleArn: "arn:aws:iam::123456789012:role/test"})
+		if base.Region != "us-east-1" {
+			t.Errorf("base.Region was mutated to %q", base.Region)
+		}
+		if base.Credentials != nil {
+			t.Error("base.Credentials was mutated")
+		}
+	})
+}
+
+func TestProberRegistry_scopedRegistry(t *testing.T) {
+	cfg := aws.Config{Region: "us-east-1"}
+	registry := NewProberRegistry(cfg)
+
+	t.Run("same overrides reuse the scoped registry", func(t *testing.T) {
+		overrides := RegionRoleOverrides{Region: "us-west-2", AssumeRoleArn: "arn:aws:iam::123456789012:role/test"}
+		first, err := registry.scopedRegistry(overrides)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		second, err := registry.scopedRegistry(overrides)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if first != second {
+			t.Error("expected the same scoped registry instance to be reused")
+		}
+	})
+
+	t.Run("differing external id does not reuse the scoped registry", func(t *testing.T) {
+		base := RegionRoleOverrides{Region: "ap-south-1", AssumeRoleArn: "arn:aws:iam::123456789012:role/test"}
+		withExternalID := base
+		withExternalID.ExternalID = "external-id"
+
+		first, err := registry.scopedRegistry(base)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		second, err := registry.scopedRegistry(withExternalID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if first == second {
+			t.Error("expected a distinct scoped registry when external_id differs")
+		}
+	})
+
+	t.Run("differing session name does not reuse the scoped registry", func(t *testing.T) {
+		base := RegionRoleOverrides{Region: "ca-central-1", AssumeRoleArn: "arn:aws:iam::123456789012:role/test"}
+		withSessionName := base
+		withSessionName.SessionName = "session-a"
+
+		first, err := registry.scopedRegistry(base)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		second, err := registry.scopedRegistry(withSessionName)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if first == second {
+			t.Error("expected a distinct scoped registry when session_name differs")
+		}
+	})
+}
+
 func TestNewProberRegistry(t *testing.T) {
 	cfg := aws.Config{Region: "us-west-2"}
 	registry := NewProberRegistry(cfg)
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Prober checks for the existence of an S3 bucket and reports its ARN and
+// tags.
+type S3Prober struct {
+	client *s3.Client
+}
+
+// NewS3Prober returns a Prober for AWS::S3::Bucket backed by cfg.
+func NewS3Prober(cfg aws.Config) *S3Prober {
+	return &S3Prober{
+		client: s3.NewFromConfig(cfg, func(o *s3.Options) {
+			o.UsePathStyle = true
+		}),
+	}
+}
+
+// Probe checks whether the bucket named id exists and, if so, collects its
+// ARN and tags.
+func (p *S3Prober) Probe(ctx context.Context, id string) (*ProbeResult, error) {
+	_, err := p.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(id)})
+	if err != nil {
+		if isS3NotFound(err) {
+			return &ProbeResult{Exists: false}, nil
+		}
+		return nil, fmt.Errorf("probing S3 bucket %q: %w", id, err)
+	}
+
+	result := &ProbeResult{
+		Exists: true,
+		Arn:    "arn:aws:s3:::" + id,
+		Properties: map[string]interface{}{
+			"BucketName": id,
+			// S3 buckets have no "provisioning" state of their own: a
+			// successful HeadBucket means the bucket is immediately usable.
+			// This lets wait_for treat buckets the same way as resources
+			// (like DynamoDB tables) that do have a real status field.
+			"Ready": true,
+		},
+	}
+
+	tags, err := bucketTags(ctx, p.client, id)
+	if err != nil {
+		return nil, fmt.Errorf("reading tags for S3 bucket %q: %w", id, err)
+	}
+	if len(tags) > 0 {
+		result.Tags = tags
+		result.Properties["Tags"] = tags
+	}
+
+	return result, nil
+}
+
+// bucketTags fetches the tag set for bucket, treating "no tags configured"
+// as an empty (not missing) result.
+func bucketTags(ctx context.Context, client *s3.Client, bucket string) (map[string]string, error) {
+	out, err := client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		if contains(err.Error(), "NoSuchTagSet") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	tags := make(map[string]string, len(out.TagSet))
+	for _, tag := range out.TagSet {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags, nil
+}
+
+// isS3NotFound reports whether err represents an S3 "does not exist"
+// response, as opposed to e.g. an access-denied or network error.
+func isS3NotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return contains(msg, "404") ||
+		contains(msg, "NotFound") ||
+		contains(msg, "NoSuchBucket") ||
+		contains(msg, "NoSuchKey")
+}
+
+// contains reports whether substr occurs within s. It exists so callers can
+// match on raw AWS error strings without pulling in a specific error type
+// for every service.
+func contains(s, substr string) bool {
+	return strings.Contains(s, substr)
+}
+
+// S3ObjectProber checks for the existence of an individual S3 object and
+// reports its content metadata and tags. The id is given as "bucket/key".
+type S3ObjectProber struct {
+	client *s3.Client
+}
+
+// NewS3ObjectProber returns a Prober for AWS::S3::Object backed by cfg.
+func NewS3ObjectProber(cfg aws.Config) *S3ObjectProber {
+	return &S3ObjectProber{
+		client: s3.NewFromConfig(cfg, func(o *s3.Options) {
+			o.UsePathStyle = true
+		}),
+	}
+}
+
+// Probe checks whether the object identified by "bucket/key" exists and, if
+// so, collects its content metadata and tags.
+func (p *S3ObjectProber) Probe(ctx context.Context, id string) (*ProbeResult, error) {
+	bucket, key, err := splitBucketKey(id)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := p.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isS3NotFound(err) {
+			return &ProbeResult{Exists: false}, nil
+		}
+		return nil, fmt.Errorf("probing S3 object %q: %w", id, err)
+	}
+
+	properties := map[string]interface{}{
+		"ContentLength": aws.ToInt64(head.ContentLength),
+		"ETag":          aws.ToString(head.ETag),
+		"ContentType":   aws.ToString(head.ContentType),
+		"StorageClass":  string(head.StorageClass),
+		"VersionId":     aws.ToString(head.VersionId),
+	}
+	if head.ServerSideEncryption != "" {
+		properties["ServerSideEncryption"] = string(head.ServerSideEncryption)
+	}
+	if head.LastModified != nil {
+		properties["LastModified"] = head.LastModified.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	result := &ProbeResult{
+		Exists:     true,
+		Arn:        fmt.Sprintf("arn:aws:s3:::%s/%s", bucket, key),
+		Properties: properties,
+	}
+
+	tags, err := objectTags(ctx, p.client, bucket, key)
+	if err != nil {
+		return nil, fmt.Errorf("reading tags for S3 object %q: %w", id, err)
+	}
+	if len(tags) > 0 {
+		result.Tags = tags
+		result.Properties["Tags"] = tags
+	}
+
+	return result, nil
+}
+
+// objectTags fetches the tag set for the object at bucket/key, treating
+// "no tags configured" as an empty (not missing) result.
+func objectTags(ctx context.Context, client *s3.Client, bucket, key string) (map[string]string, error) {
+	out, err := client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if contains(err.Error(), "NoSuchTagSet") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	tags := make(map[string]string, len(out.TagSet))
+	for _, tag := range out.TagSet {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags, nil
+}
+
+// splitBucketKey parses an S3 object id of the form "bucket/key".
+func splitBucketKey(id string) (bucket, key string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid S3 object id %q: expected \"bucket/key\"", id)
+	}
+	return parts[0], parts[1], nil
+}
@@ -6,6 +6,7 @@ package provider
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -119,6 +120,11 @@ func TestIsS3NotFound(t *testing.T) {
 			err:      errors.New("NoSuchBucket: The specified bucket does not exist"),
 			expected: true,
 		},
+		{
+			name:     "error contains NoSuchKey",
+			err:      errors.New("NoSuchKey: The specified key does not exist"),
+			expected: true,
+		},
 		{
 			name:     "generic error",
 			err:      errors.New("access denied"),
@@ -291,3 +297,133 @@ func TestS3Prober_BucketWithTags(t *testing.T) {
 		t.Errorf("expected Properties.Tags.Environment='test', got %q", propTags["Environment"])
 	}
 }
+
+func TestS3ObjectProber_ObjectNotFound(t *testing.T) {
+	cfg := getLocalStackConfig(t)
+	if cfg == nil {
+		t.Skip("LocalStack not available")
+	}
+
+	prober := NewS3ObjectProber(*cfg)
+	result, err := prober.Probe(context.Background(), "nonexistent-bucket-12345-xyz/nonexistent-key")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Exists {
+		t.Error("expected Exists to be false for nonexistent object")
+	}
+}
+
+func TestS3ObjectProber_ObjectExists(t *testing.T) {
+	cfg := getLocalStackConfig(t)
+	if cfg == nil {
+		t.Skip("LocalStack not available")
+	}
+
+	ctx := context.Background()
+	client := s3.NewFromConfig(*cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+	})
+	bucketName := "probe-test-s3-object-exists"
+	key := "config/app.yaml"
+
+	_, err := client.CreateBucket(ctx, &s3.CreateBucketInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		t.Fatalf("failed to create test bucket: %v", err)
+	}
+
+	_, err = client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucketName),
+		Key:         aws.String(key),
+		Body:        strings.NewReader("name: probe"),
+		ContentType: aws.String("application/yaml"),
+		Tagging:     aws.String("Environment=test"),
+	})
+	if err != nil {
+		t.Fatalf("failed to put test object: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_, _ = client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String(bucketName), Key: aws.String(key)})
+		_, _ = client.DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: aws.String(bucketName)})
+	})
+
+	prober := NewS3ObjectProber(*cfg)
+	result, err := prober.Probe(ctx, bucketName+"/"+key)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.Exists {
+		t.Error("expected Exists to be true for existing object")
+	}
+
+	expectedArn := "arn:aws:s3:::" + bucketName + "/" + key
+	if result.Arn != expectedArn {
+		t.Errorf("expected ARN=%q, got %q", expectedArn, result.Arn)
+	}
+
+	if result.Properties["ContentType"] != "application/yaml" {
+		t.Errorf("expected ContentType=%q, got %q", "application/yaml", result.Properties["ContentType"])
+	}
+
+	if result.Tags["Environment"] != "test" {
+		t.Errorf("expected Environment tag='test', got %q", result.Tags["Environment"])
+	}
+}
+
+func TestSplitBucketKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          string
+		wantBucket  string
+		wantKey     string
+		expectError bool
+	}{
+		{
+			name:       "simple bucket and key",
+			id:         "my-bucket/my-key",
+			wantBucket: "my-bucket",
+			wantKey:    "my-key",
+		},
+		{
+			name:       "key with nested path",
+			id:         "my-bucket/path/to/object.json",
+			wantBucket: "my-bucket",
+			wantKey:    "path/to/object.json",
+		},
+		{
+			name:        "missing key",
+			id:          "my-bucket",
+			expectError: true,
+		},
+		{
+			name:        "empty id",
+			id:          "",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket, key, err := splitBucketKey(tt.id)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if bucket != tt.wantBucket || key != tt.wantKey {
+				t.Errorf("splitBucketKey(%q) = (%q, %q), want (%q, %q)", tt.id, bucket, key, tt.wantBucket, tt.wantKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure ProbeProvider satisfies various provider interfaces.
+var _ provider.Provider = &ProbeProvider{}
+
+// ProbeProvider is the provider implementation.
+type ProbeProvider struct {
+	// version is set to the provider version on release, "dev" when the
+	// provider is built and run locally, and "test" when running
+	// acceptance testing.
+	version string
+}
+
+// ProbeProviderModel describes the provider data model.
+type ProbeProviderModel struct {
+	Region         types.String         `tfsdk:"region"`
+	LocalStack     types.Bool           `tfsdk:"localstack"`
+	MaxConcurrency types.Int64          `tfsdk:"max_concurrency"`
+	Backends       []backendConfigModel `tfsdk:"backends"`
+}
+
+// backendConfigModel describes a single entry of the provider's `backends`
+// block: a named, non-AWS, S3-API-compatible object store.
+type backendConfigModel struct {
+	Name           types.String `tfsdk:"name"`
+	Endpoint       types.String `tfsdk:"endpoint"`
+	Region         types.String `tfsdk:"region"`
+	CredentialsRef types.String `tfsdk:"credentials_ref"`
+}
+
+// probeProviderData is what gets handed to data sources via
+// resp.DataSourceData / req.ProviderData.
+type probeProviderData struct {
+	Registry       *ProberRegistry
+	MaxConcurrency int
+}
+
+// defaultMaxConcurrency is how many IDs probe_many probes at once when
+// max_concurrency isn't set on the provider.
+const defaultMaxConcurrency = 8
+
+func (p *ProbeProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "probe"
+	resp.Version = p.version
+}
+
+func (p *ProbeProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The probe provider checks for the existence of cloud resources, without managing their lifecycle.",
+		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Description: "AWS region to probe resources in. Defaults to the standard AWS SDK resolution chain.",
+			},
+			"localstack": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Point the provider at a local LocalStack instance instead of real AWS.",
+			},
+			"max_concurrency": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of IDs probed in parallel by `probe_many`. Defaults to 8.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"backends": schema.ListNestedBlock{
+				Description: "Named non-AWS, S3-API-compatible backends (e.g. DigitalOcean Spaces, MinIO, Cloudflare R2). Addressed from a data source's `type` as `\"<name>:<resourceType>\"`.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "Name used to address this backend, e.g. `spaces`.",
+						},
+						"endpoint": schema.StringAttribute{
+							Required:    true,
+							Description: "S3-compatible endpoint URL, e.g. `https://nyc3.digitaloceanspaces.com`.",
+						},
+						"region": schema.StringAttribute{
+							Optional:    true,
+							Description: "Region to sign requests with. Many S3-compatible services accept any non-empty value here.",
+						},
+						"credentials_ref": schema.StringAttribute{
+							Optional:    true,
+							Description: "Prefix of the environment variables holding credentials for this backend, read as `<credentials_ref>_ACCESS_KEY_ID` and `<credentials_ref>_SECRET_ACCESS_KEY`. If unset, the standard AWS SDK credential chain is used.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p *ProbeProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var data ProbeProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var opts []func(*config.LoadOptions) error
+	if !data.Region.IsNull() && data.Region.ValueString() != "" {
+		opts = append(opts, config.WithRegion(data.Region.ValueString()))
+	}
+	if !data.LocalStack.IsNull() && data.LocalStack.ValueBool() {
+		if data.Region.IsNull() || data.Region.ValueString() == "" {
+			opts = append(opts, config.WithRegion("us-east-1"))
+		}
+		opts = append(opts, config.WithBaseEndpoint("http://localhost:4566"))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Load AWS Configuration", err.Error())
+		return
+	}
+
+	maxConcurrency := defaultMaxConcurrency
+	if !data.MaxConcurrency.IsNull() && data.MaxConcurrency.ValueInt64() > 0 {
+		maxConcurrency = int(data.MaxConcurrency.ValueInt64())
+	}
+
+	registry := NewProberRegistry(cfg)
+	for _, b := range data.Backends {
+		accessKeyID, secretAccessKey := credentialsFromRef(b.CredentialsRef.ValueString())
+		registry.RegisterBackend(b.Name.ValueString(), S3CompatibleBackend{
+			Endpoint:        b.Endpoint.ValueString(),
+			Region:          b.Region.ValueString(),
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+		})
+	}
+
+	resp.DataSourceData = &probeProviderData{
+		Registry:       registry,
+		MaxConcurrency: maxConcurrency,
+	}
+}
+
+// credentialsFromRef reads static credentials for a backend from
+// "<ref>_ACCESS_KEY_ID" / "<ref>_SECRET_ACCESS_KEY" environment variables. An
+// empty ref yields no credentials, so the backend falls back to the
+// standard AWS SDK credential chain.
+func credentialsFromRef(ref string) (accessKeyID, secretAccessKey string) {
+	if ref == "" {
+		return "", ""
+	}
+	return os.Getenv(ref + "_ACCESS_KEY_ID"), os.Getenv(ref + "_SECRET_ACCESS_KEY")
+}
+
+func (p *ProbeProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *ProbeProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		NewProbeDataSource,
+		NewProbeManyDataSource,
+	}
+}
+
+// New returns a provider server factory for the probe provider.
+func New(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &ProbeProvider{version: version}
+	}
+}